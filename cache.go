@@ -0,0 +1,88 @@
+package onfido
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// CachedClient wraps a Client with a DocumentStore, serving repeated
+// document and live photo downloads from the store when possible and
+// revalidating against the Onfido API using ETags. Construct one with
+// Client.WithCache.
+type CachedClient struct {
+	*Client
+	Store DocumentStore
+}
+
+// WithCache returns a CachedClient that serves DownloadDocument and
+// GetLivePhotoDownload from store, keyed by "applicantID/documentID" (or
+// the live photo ID), so services can persist KYC evidence to durable
+// storage without every caller reimplementing the revalidation dance.
+func (c *Client) WithCache(store DocumentStore) *CachedClient {
+	return &CachedClient{Client: c, Store: store}
+}
+
+// DownloadDocument serves the document from the cache when it has not
+// changed on the Onfido side, and otherwise downloads it and refreshes the
+// cache entry.
+// see https://documentation.onfido.com/?shell#download-document
+func (cc *CachedClient) DownloadDocument(ctx context.Context, applicantID, id string) (*DocumentDownload, error) {
+	key := applicantID + "/" + id
+	return cc.cachedDownload(ctx, key, func(opts DownloadOptions) (*DocumentDownload, error) {
+		return cc.Client.DownloadDocument(ctx, applicantID, id, opts)
+	})
+}
+
+// GetLivePhotoDownload serves the live photo from the cache when it has
+// not changed on the Onfido side, and otherwise downloads it and refreshes
+// the cache entry.
+// see https://documentation.onfido.com/?shell#download-live-photo
+func (cc *CachedClient) GetLivePhotoDownload(ctx context.Context, livePhotoID string) (*DocumentDownload, error) {
+	return cc.cachedDownload(ctx, livePhotoID, func(opts DownloadOptions) (*DocumentDownload, error) {
+		return cc.Client.GetLivePhotoDownload(ctx, livePhotoID, opts)
+	})
+}
+
+// cachedDownload revalidates the cache entry for key against the origin
+// using its recorded ETag, serving the cached blob on a 304 and refreshing
+// the cache entry otherwise.
+func (cc *CachedClient) cachedDownload(ctx context.Context, key string, fetch func(DownloadOptions) (*DocumentDownload, error)) (*DocumentDownload, error) {
+	var opts DownloadOptions
+	if cached, err := cc.Store.Stat(ctx, key); err == nil {
+		opts.IfNoneMatch = cached.ETag
+	}
+
+	dd, err := fetch(opts)
+	if err == ErrNotModified {
+		r, cached, getErr := cc.Store.Get(ctx, key)
+		if getErr != nil {
+			return nil, getErr
+		}
+		defer r.Close()
+
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, r); err != nil {
+			return nil, err
+		}
+		return &DocumentDownload{
+			Content:     buf.Bytes(),
+			Size:        buf.Len(),
+			ContentType: cached.ContentType,
+			ETag:        cached.ETag,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cc.Store.Put(ctx, key, bytes.NewReader(dd.Content), Meta{
+		ContentType: dd.ContentType,
+		Size:        int64(dd.Size),
+		ETag:        dd.ETag,
+	}); err != nil {
+		return nil, err
+	}
+
+	return dd, nil
+}
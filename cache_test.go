@@ -0,0 +1,137 @@
+package onfido_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStore is a DocumentStore whose Put always fails, used to assert
+// that CachedClient surfaces cache-write errors instead of swallowing
+// them.
+type failingStore struct{}
+
+func (failingStore) Put(ctx context.Context, key string, r io.Reader, meta onfido.Meta) error {
+	return errors.New("store: put failed")
+}
+
+func (failingStore) Get(ctx context.Context, key string) (io.ReadCloser, onfido.Meta, error) {
+	return nil, onfido.Meta{}, onfido.ErrNotFound
+}
+
+func (failingStore) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func (failingStore) Stat(ctx context.Context, key string) (onfido.Meta, error) {
+	return onfido.Meta{}, onfido.ErrNotFound
+}
+
+func TestCachedClient_DownloadDocument_RevalidatesAndCaches(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+	content := []byte("hello world")
+
+	var requests int
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", `"abc123"`)
+		if r.Header.Get("If-None-Match") == `"abc123"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	store, err := onfido.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := client.WithCache(store)
+
+	dd, err := cached.DownloadDocument(context.Background(), applicantID, documentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, content, dd.Content)
+	assert.Equal(t, 1, requests)
+
+	dd, err = cached.DownloadDocument(context.Background(), applicantID, documentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, content, dd.Content)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCachedClient_DownloadDocument_PropagatesContentType(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc123"`)
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("hello world"))
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	store, err := onfido.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := client.WithCache(store)
+
+	dd, err := cached.DownloadDocument(context.Background(), applicantID, documentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "image/png", dd.ContentType)
+
+	meta, err := store.Stat(context.Background(), applicantID+"/"+documentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "image/png", meta.ContentType)
+}
+
+func TestCachedClient_DownloadDocument_ReturnsStoreError(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("hello world"))
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	cached := client.WithCache(failingStore{})
+
+	_, err := cached.DownloadDocument(context.Background(), applicantID, documentID)
+	assert.EqualError(t, err, "store: put failed")
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
@@ -70,6 +71,20 @@ type Documents struct {
 type DocumentDownload struct {
 	Size    int
 	Content []byte
+
+	// ContentType is the Content-Type reported by the server for this
+	// content.
+	ContentType string
+	// NotModified is true when the download was conditional (see
+	// DownloadOptions) and the server responded 304 Not Modified.
+	NotModified bool
+	// ETag is the ETag reported by the server for this content.
+	ETag string
+	// LastModified is the Last-Modified time reported by the server, if any.
+	LastModified time.Time
+	// ContentRange is the Content-Range reported by the server for a
+	// ranged request, if any.
+	ContentRange string
 }
 
 func (d *DocumentDownload) Write(data []byte) (n int, err error) {
@@ -146,6 +161,14 @@ func (c *Client) UploadDocument(ctx context.Context, applicantID string, dr Docu
 		return nil, err
 	}
 
+	// body is a *bytes.Buffer, which is drained by the time a retry
+	// middleware would want to resend it. Snapshot it now so req.GetBody
+	// can hand out a fresh reader on every attempt.
+	snapshot := body.Bytes()
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(snapshot)), nil
+	}
+
 	var resp Document
 	_, err = c.do(ctx, req, &resp)
 
@@ -165,16 +188,6 @@ func (c *Client) GetDocument(ctx context.Context, applicantID, id string) (*Docu
 	return &resp, err
 }
 
-func (c *Client) DownloadDocument(ctx context.Context, applicantID, id string) (*DocumentDownload, error) {
-	req, err := c.newRequest("GET", "/applicants/"+applicantID+"/documents/"+id+"/download", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	blob, err := c.download(ctx, req, &resp)
-	return &DocumentDownload{Content: blob, Size: len(blob)}, err
-}
-
 // DocumentIter represents a document iterator
 type DocumentIter struct {
 	*iter
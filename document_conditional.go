@@ -0,0 +1,57 @@
+package onfido
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotModified is returned by DownloadDocument and GetLivePhotoDownload
+// when the server responds with 304 Not Modified to a conditional request.
+var ErrNotModified = errors.New("onfido: not modified")
+
+// ByteRange represents an HTTP byte range for a ranged download request.
+// End of zero means "to the end of the resource".
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+func (r ByteRange) header() string {
+	if r.End > 0 {
+		return fmt.Sprintf("bytes=%d-%d", r.Start, r.End)
+	}
+	return fmt.Sprintf("bytes=%d-", r.Start)
+}
+
+// DownloadOptions makes a document or live photo download conditional on
+// the caller's local cache state, or restricts it to a byte range of the
+// underlying file.
+type DownloadOptions struct {
+	// IfNoneMatch, when set, is sent as the If-None-Match header.
+	IfNoneMatch string
+	// IfModifiedSince, when non-zero, is sent as the If-Modified-Since header.
+	IfModifiedSince time.Time
+	// Range, when set, is sent as the Range header.
+	Range *ByteRange
+}
+
+func (o DownloadOptions) apply(req *http.Request) {
+	if o.IfNoneMatch != "" {
+		req.Header.Set("If-None-Match", o.IfNoneMatch)
+	}
+	if !o.IfModifiedSince.IsZero() {
+		req.Header.Set("If-Modified-Since", o.IfModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	if o.Range != nil {
+		req.Header.Set("Range", o.Range.header())
+	}
+}
+
+func firstDownloadOption(opts []DownloadOptions) DownloadOptions {
+	if len(opts) == 0 {
+		return DownloadOptions{}
+	}
+	return opts[0]
+}
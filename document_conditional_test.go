@@ -0,0 +1,66 @@
+package onfido_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadDocument_NotModified(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `"abc123"`, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"abc123"`)
+		w.WriteHeader(http.StatusNotModified)
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	dd, err := client.DownloadDocument(context.Background(), applicantID, documentID, onfido.DownloadOptions{
+		IfNoneMatch: `"abc123"`,
+	})
+	assert.Equal(t, onfido.ErrNotModified, err)
+	assert.True(t, dd.NotModified)
+	assert.Equal(t, `"abc123"`, dd.ETag)
+}
+
+func TestDownloadDocumentTo_Range(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "bytes=0-4", r.Header.Get("Range"))
+		w.Header().Set("Content-Range", "bytes 0-4/11")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("hello"))
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	var buf bytes.Buffer
+	_, meta, err := client.DownloadDocumentTo(context.Background(), applicantID, documentID, &buf, onfido.DownloadOptions{
+		Range: &onfido.ByteRange{Start: 0, End: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "hello", buf.String())
+	assert.Equal(t, "bytes 0-4/11", meta.ContentRange)
+}
@@ -0,0 +1,113 @@
+package onfido
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// DownloadMeta contains metadata about a downloaded document or live photo,
+// derived from the HTTP response headers.
+type DownloadMeta struct {
+	ContentType   string
+	ContentLength int64
+	ETag          string
+	FileName      string
+	LastModified  time.Time
+	ContentRange  string
+	NotModified   bool
+}
+
+// download performs req and streams the response body into w, returning the
+// number of bytes written along with metadata parsed from the response
+// headers. A 304 response is not treated as an error: the returned meta has
+// NotModified set to true and no body is copied.
+func (c *Client) download(ctx context.Context, req *http.Request, w io.Writer, opts DownloadOptions) (int64, DownloadMeta, error) {
+	opts.apply(req)
+
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, DownloadMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	meta := DownloadMeta{
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+		ETag:          resp.Header.Get("ETag"),
+		ContentRange:  resp.Header.Get("Content-Range"),
+	}
+	if cd := resp.Header.Get("Content-Disposition"); cd != "" {
+		if _, params, err := mime.ParseMediaType(cd); err == nil {
+			meta.FileName = params["filename"]
+		}
+	}
+	if lm := resp.Header.Get("Last-Modified"); lm != "" {
+		if t, err := time.Parse(http.TimeFormat, lm); err == nil {
+			meta.LastModified = t
+		}
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		meta.NotModified = true
+		return 0, meta, nil
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return 0, DownloadMeta{}, fmt.Errorf("onfido: unexpected status code %d downloading %s", resp.StatusCode, req.URL.Path)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	return n, meta, err
+}
+
+// DownloadDocumentTo streams the raw content of a document for the provided
+// applicant into w, returning the number of bytes written and metadata
+// parsed from the response headers, without buffering the whole file in
+// memory. Passing DownloadOptions allows the request to be made
+// conditional (If-None-Match / If-Modified-Since) or restricted to a byte
+// range; when the server responds 304 Not Modified, no bytes are written to
+// w and the returned DownloadMeta has NotModified set to true.
+// see https://documentation.onfido.com/?shell#download-document
+func (c *Client) DownloadDocumentTo(ctx context.Context, applicantID, id string, w io.Writer, opts ...DownloadOptions) (int64, DownloadMeta, error) {
+	req, err := c.newRequest("GET", "/applicants/"+applicantID+"/documents/"+id+"/download", nil)
+	if err != nil {
+		return 0, DownloadMeta{}, err
+	}
+	return c.download(ctx, req, w, firstDownloadOption(opts))
+}
+
+// DownloadDocument retrieves the content of a document for the provided
+// applicant. It is a thin wrapper around DownloadDocumentTo kept for
+// backward compatibility; prefer DownloadDocumentTo for large files to
+// avoid buffering the whole content in memory.
+//
+// Passing DownloadOptions makes the request conditional; if the server
+// responds 304 Not Modified, ErrNotModified is returned alongside a
+// DocumentDownload with NotModified set to true, letting callers keep
+// serving their cached copy.
+// see https://documentation.onfido.com/?shell#download-document
+func (c *Client) DownloadDocument(ctx context.Context, applicantID, id string, opts ...DownloadOptions) (*DocumentDownload, error) {
+	var buf bytes.Buffer
+	n, meta, err := c.DownloadDocumentTo(ctx, applicantID, id, &buf, firstDownloadOption(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	dd := &DocumentDownload{
+		Content:      buf.Bytes(),
+		Size:         int(n),
+		ContentType:  meta.ContentType,
+		NotModified:  meta.NotModified,
+		ETag:         meta.ETag,
+		LastModified: meta.LastModified,
+		ContentRange: meta.ContentRange,
+	}
+	if dd.NotModified {
+		return dd, ErrNotModified
+	}
+	return dd, nil
+}
@@ -0,0 +1,67 @@
+package onfido_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadDocumentTo(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	documentID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+
+	dummyContent := []byte("hello world")
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents/{documentId}/download", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		assert.Equal(t, applicantID, vars["applicantId"])
+		assert.Equal(t, documentID, vars["documentId"])
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("ETag", `"abc123"`)
+		w.Header().Set("Content-Disposition", `attachment; filename="passport.png"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write(dummyContent)
+	}).Methods("GET")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	var buf bytes.Buffer
+	n, meta, err := client.DownloadDocumentTo(context.Background(), applicantID, documentID, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(len(dummyContent)), n)
+	assert.Equal(t, dummyContent, buf.Bytes())
+	assert.Equal(t, "image/png", meta.ContentType)
+	assert.Equal(t, `"abc123"`, meta.ETag)
+	assert.Equal(t, "passport.png", meta.FileName)
+}
+
+func TestDownloadDocumentTo_NonOKResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("{\"error\": \"things went bad\"}"))
+	}))
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	var buf bytes.Buffer
+	_, _, err := client.DownloadDocumentTo(context.Background(), "", "", &buf)
+	if err == nil {
+		t.Fatal("expected server to return non ok response, got successful response")
+	}
+}
@@ -0,0 +1,162 @@
+package onfido
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// Errors returned by UploadDocumentStream when the supplied file fails validation
+// before any request is sent to the Onfido API.
+var (
+	// ErrFileTooLarge is returned when the file exceeds UploadOptions.MaxFileSize.
+	ErrFileTooLarge = errors.New("onfido: file exceeds maximum allowed size")
+	// ErrUnsupportedMediaType is returned when the sniffed content type of the
+	// file is not listed in UploadOptions.AllowedMimeTypes.
+	ErrUnsupportedMediaType = errors.New("onfido: unsupported file media type")
+)
+
+// UploadOptions configures the behaviour of UploadDocumentStream.
+type UploadOptions struct {
+	// MaxFileSize is the maximum number of bytes allowed for the uploaded
+	// file. Zero means no limit is enforced.
+	MaxFileSize int64
+
+	// AllowedMimeTypes restricts the set of content types, as sniffed from
+	// the first 512 bytes of the file, that may be uploaded. A nil or empty
+	// slice allows any content type.
+	AllowedMimeTypes []string
+
+	// Progress, when set, is written to with the cumulative number of bytes
+	// copied into the request body as the upload proceeds.
+	Progress io.Writer
+}
+
+// progressCounter wraps an io.Reader, reporting the cumulative number of
+// bytes read to Progress after every Read call.
+type progressCounter struct {
+	r        io.Reader
+	total    int64
+	progress io.Writer
+}
+
+func (p *progressCounter) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.total += int64(n)
+	if p.progress != nil {
+		fmt.Fprintf(p.progress, "%d\n", p.total)
+	}
+	return n, err
+}
+
+// seekerSize returns the total size of an io.ReadSeeker without disturbing
+// its current position.
+func seekerSize(rs io.ReadSeeker) (int64, error) {
+	current, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	size, err := rs.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := rs.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func containsMimeType(types []string, mimeType string) bool {
+	for _, t := range types {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadDocumentStream uploads a document for the provided applicant without
+// buffering the whole multipart body in memory: the multipart.Writer runs in
+// a goroutine and feeds an io.Pipe that is used directly as the request
+// body. This is preferable to UploadDocument for large ID scans and bank
+// statements.
+//
+// Before the request is sent, the file's sniffed content type and size are
+// validated against opts, returning ErrUnsupportedMediaType or
+// ErrFileTooLarge respectively.
+//
+// see https://documentation.onfido.com/?shell#upload-document
+func (c *Client) UploadDocumentStream(ctx context.Context, applicantID string, dr DocumentRequest, filename string, opts UploadOptions) (*Document, error) {
+	buffer := make([]byte, 512)
+	if _, err := dr.File.Read(buffer); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if _, err := dr.File.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	if opts.MaxFileSize > 0 {
+		size, err := seekerSize(dr.File)
+		if err != nil {
+			return nil, err
+		}
+		if size > opts.MaxFileSize {
+			return nil, ErrFileTooLarge
+		}
+	}
+
+	if contentType := http.DetectContentType(buffer); len(opts.AllowedMimeTypes) > 0 && !containsMimeType(opts.AllowedMimeTypes, contentType) {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := createFormFile(writer, "file", dr.File, filename)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		var src io.Reader = dr.File
+		if opts.Progress != nil {
+			src = &progressCounter{r: dr.File, progress: opts.Progress}
+		}
+
+		if _, err := io.Copy(part, src); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := writer.WriteField("type", string(dr.Type)); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := writer.WriteField("side", string(dr.Side)); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := writer.WriteField("issuing_country", dr.IssuingCountry); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := writer.Close(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.Close()
+	}()
+
+	req, err := c.newRequest("POST", "/applicants/"+applicantID+"/documents", pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var resp Document
+	_, err = c.do(ctx, req, &resp)
+	return &resp, err
+}
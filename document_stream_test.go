@@ -0,0 +1,90 @@
+package onfido_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadDocumentStream_FileTooLarge(t *testing.T) {
+	client := onfido.NewClient("123")
+
+	docReq := onfido.DocumentRequest{
+		File: bytes.NewReader([]byte("this file is definitely too big")),
+		Type: onfido.DocumentTypeIDCard,
+		Side: onfido.DocumentSideFront,
+	}
+
+	_, err := client.UploadDocumentStream(context.Background(), "", docReq, "test.txt", onfido.UploadOptions{
+		MaxFileSize: 4,
+	})
+	assert.Equal(t, onfido.ErrFileTooLarge, err)
+}
+
+func TestUploadDocumentStream_UnsupportedMediaType(t *testing.T) {
+	client := onfido.NewClient("123")
+
+	docReq := onfido.DocumentRequest{
+		File: bytes.NewReader([]byte("plain text content")),
+		Type: onfido.DocumentTypeIDCard,
+		Side: onfido.DocumentSideFront,
+	}
+
+	_, err := client.UploadDocumentStream(context.Background(), "", docReq, "test.txt", onfido.UploadOptions{
+		AllowedMimeTypes: []string{"image/png"},
+	})
+	assert.Equal(t, onfido.ErrUnsupportedMediaType, err)
+}
+
+func TestUploadDocumentStream_DocumentUploaded(t *testing.T) {
+	applicantID := "541d040b-89f8-444b-8921-16b1333bf1c6"
+	expected := onfido.Document{
+		ID:   "ce62d838-56f8-4ea5-98be-e7166d1dc33d",
+		Type: onfido.DocumentTypePassport,
+		Side: onfido.DocumentSideBack,
+	}
+	expectedJson, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := mux.NewRouter()
+	m.HandleFunc("/applicants/{applicantId}/documents", func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		assert.Equal(t, applicantID, vars["applicantId"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(expectedJson)
+	}).Methods("POST")
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	var progress bytes.Buffer
+	d, err := client.UploadDocumentStream(context.Background(), applicantID, onfido.DocumentRequest{
+		File: bytes.NewReader([]byte("test content")),
+		Type: expected.Type,
+		Side: expected.Side,
+	}, "test.txt", onfido.UploadOptions{
+		MaxFileSize: 1 << 20,
+		Progress:    &progress,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, expected.ID, d.ID)
+	assert.Equal(t, expected.Type, d.Type)
+	assert.Equal(t, expected.Side, d.Side)
+	assert.NotEmpty(t, progress.String())
+}
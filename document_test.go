@@ -256,7 +256,7 @@ func TestDownloadDocument(t *testing.T) {
 	assert.Equal(t, expected.Content, dd.Content)
 }
 
-func TestDownloadDocument(t *testing.T) {
+func TestGetLivePhotoDownload(t *testing.T) {
 	livePhotoID := "ce62d838-56f8-4ea5-98be-e7166d1dc33d"
 
 	dummy_live_photo := []byte("hi pretty")
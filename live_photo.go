@@ -0,0 +1,52 @@
+package onfido
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// GetLivePhotoDownloadTo streams the raw content of a live photo into w,
+// returning the number of bytes written and metadata parsed from the
+// response headers, without buffering the whole file in memory. Passing
+// DownloadOptions allows the request to be made conditional or restricted
+// to a byte range, as with DownloadDocumentTo.
+// see https://documentation.onfido.com/?shell#download-live-photo
+func (c *Client) GetLivePhotoDownloadTo(ctx context.Context, livePhotoID string, w io.Writer, opts ...DownloadOptions) (int64, DownloadMeta, error) {
+	req, err := c.newRequest("GET", "/live_photos/"+livePhotoID+"/download", nil)
+	if err != nil {
+		return 0, DownloadMeta{}, err
+	}
+	return c.download(ctx, req, w, firstDownloadOption(opts))
+}
+
+// GetLivePhotoDownload retrieves the content of a live photo. It is a thin
+// wrapper around GetLivePhotoDownloadTo kept for backward compatibility;
+// prefer GetLivePhotoDownloadTo for large files to avoid buffering the
+// whole content in memory.
+//
+// Passing DownloadOptions makes the request conditional; if the server
+// responds 304 Not Modified, ErrNotModified is returned alongside a
+// DocumentDownload with NotModified set to true.
+// see https://documentation.onfido.com/?shell#download-live-photo
+func (c *Client) GetLivePhotoDownload(ctx context.Context, livePhotoID string, opts ...DownloadOptions) (*DocumentDownload, error) {
+	var buf bytes.Buffer
+	n, meta, err := c.GetLivePhotoDownloadTo(ctx, livePhotoID, &buf, firstDownloadOption(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	dd := &DocumentDownload{
+		Content:      buf.Bytes(),
+		Size:         int(n),
+		ContentType:  meta.ContentType,
+		NotModified:  meta.NotModified,
+		ETag:         meta.ETag,
+		LastModified: meta.LastModified,
+		ContentRange: meta.ContentRange,
+	}
+	if dd.NotModified {
+		return dd, ErrNotModified
+	}
+	return dd, nil
+}
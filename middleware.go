@@ -0,0 +1,20 @@
+package onfido
+
+import "net/http"
+
+// Middleware wraps an http.RoundTripper to add cross-cutting behaviour such
+// as retries, rate limiting, or request/response hooks, without the rest of
+// the client needing to know it exists. Built-in middlewares live in the
+// onfidohttp subpackage.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// Use installs mw on the client's HTTP transport. Each call wraps the
+// previously installed transport, so the most recently registered
+// middleware runs outermost, seeing the request first and the response
+// last. Use must be called before the client makes any requests.
+func (c *Client) Use(mw Middleware) {
+	if c.HTTPClient.Transport == nil {
+		c.HTTPClient.Transport = http.DefaultTransport
+	}
+	c.HTTPClient.Transport = mw(c.HTTPClient.Transport)
+}
@@ -0,0 +1,50 @@
+package onfido_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Use(t *testing.T) {
+	var calls []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+
+	client.Use(func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "registered-first")
+			return next.RoundTrip(req)
+		})
+	})
+	client.Use(func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "registered-second")
+			return next.RoundTrip(req)
+		})
+	})
+
+	_, err := client.GetDocument(context.Background(), "", "")
+	if err == nil {
+		t.Fatal("expected server to return non ok response, got successful response")
+	}
+
+	// The most recently registered middleware runs outermost.
+	assert.Equal(t, []string{"registered-second", "registered-first"}, calls)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,102 @@
+package onfidohttp
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/getground/go-onfido"
+)
+
+// BackoffOptions configures the Backoff middleware.
+type BackoffOptions struct {
+	// MaxRetries is the maximum number of retries after the initial
+	// request. Defaults to 3.
+	MaxRetries int
+	// BaseDelay is the upper bound of the jittered delay before the first
+	// retry. Defaults to 500ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay. Defaults to 30s.
+	MaxDelay time.Duration
+}
+
+// Backoff retries requests that fail with a 429 or 5xx response, using
+// exponential backoff with full jitter. A Retry-After response header, if
+// present, takes precedence over the computed delay.
+//
+// Retrying a request with a body requires req.GetBody to be set; requests
+// whose body cannot be replayed are returned as-is after the first
+// attempt. Client.UploadDocument snapshots its body and sets GetBody
+// explicitly for this reason, so it is retried. Client.UploadDocumentStream
+// streams its body from an io.Pipe and does not set GetBody, so its
+// uploads are not retried by this middleware once the body has started
+// being read.
+func Backoff(opts BackoffOptions) onfido.Middleware {
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 500 * time.Millisecond
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 30 * time.Second
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var resp *http.Response
+			var err error
+
+			for attempt := 0; ; attempt++ {
+				if attempt > 0 && req.GetBody != nil {
+					body, gerr := req.GetBody()
+					if gerr != nil {
+						return nil, gerr
+					}
+					req.Body = body
+				}
+
+				resp, err = next.RoundTrip(req)
+				if err != nil || attempt == opts.MaxRetries {
+					return resp, err
+				}
+				if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+					return resp, nil
+				}
+				if req.GetBody == nil && req.Body != nil {
+					return resp, nil
+				}
+
+				delay := retryDelay(resp, attempt, opts)
+				resp.Body.Close()
+
+				select {
+				case <-time.After(delay):
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				}
+			}
+		})
+	}
+}
+
+func retryDelay(resp *http.Response, attempt int, opts BackoffOptions) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(ra); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+
+	backoff := float64(opts.BaseDelay) * math.Pow(2, float64(attempt))
+	if max := float64(opts.MaxDelay); backoff > max {
+		backoff = max
+	}
+	return time.Duration(rand.Float64() * backoff)
+}
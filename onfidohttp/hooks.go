@@ -0,0 +1,36 @@
+package onfidohttp
+
+import (
+	"net/http"
+
+	"github.com/getground/go-onfido"
+)
+
+// Hooks lets callers observe outgoing requests and their responses, e.g.
+// for logging or metrics, without altering them.
+type Hooks struct {
+	// OnRequest, if set, is called before a request is sent.
+	OnRequest func(*http.Request)
+	// OnResponse, if set, is called after a request completes, whether it
+	// succeeded or not. resp is nil if err is non-nil.
+	OnResponse func(req *http.Request, resp *http.Response, err error)
+}
+
+// Middleware returns an onfido.Middleware that invokes h's hooks around
+// every request.
+func (h Hooks) Middleware() onfido.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if h.OnRequest != nil {
+				h.OnRequest(req)
+			}
+
+			resp, err := next.RoundTrip(req)
+
+			if h.OnResponse != nil {
+				h.OnResponse(req, resp, err)
+			}
+			return resp, err
+		})
+	}
+}
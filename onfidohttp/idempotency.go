@@ -0,0 +1,56 @@
+package onfidohttp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/getground/go-onfido"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey returns a context under which the Idempotency
+// middleware uses key for every POST request made with it, instead of
+// generating one.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// IdempotencyKeyFunc generates an idempotency key for a request that didn't
+// have one supplied via WithIdempotencyKey.
+type IdempotencyKeyFunc func(req *http.Request) string
+
+// Idempotency injects an Idempotency-Key header on POST requests, using the
+// key supplied via WithIdempotencyKey if present, or gen otherwise. Passing
+// a nil gen generates a random 16-byte key per call. This lets retries of a
+// POST, e.g. by the Backoff middleware, be safely de-duplicated by the
+// Onfido API.
+func Idempotency(gen IdempotencyKeyFunc) onfido.Middleware {
+	if gen == nil {
+		gen = randomIdempotencyKey
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost && req.Header.Get("Idempotency-Key") == "" {
+				key, _ := req.Context().Value(idempotencyKeyCtxKey{}).(string)
+				if key == "" {
+					key = gen(req)
+				}
+				req.Header.Set("Idempotency-Key", key)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func randomIdempotencyKey(_ *http.Request) string {
+	b := make([]byte, 16)
+	// crypto/rand.Read only errors on a broken entropy source. Its error is
+	// ignored here, so in that case the key is not random and may repeat
+	// across calls.
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,63 @@
+package onfidohttp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/getground/go-onfido/onfidohttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency_InjectsHeaderOnPost(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Idempotency-Key")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+	client.Use(onfidohttp.Idempotency(nil))
+
+	req, err := http.NewRequest("POST", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	assert.NotEmpty(t, got)
+}
+
+func TestIdempotency_UsesContextKey(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Idempotency-Key")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+	client.Use(onfidohttp.Idempotency(nil))
+
+	ctx := onfidohttp.WithIdempotencyKey(context.Background(), "fixed-key")
+	req, err := http.NewRequestWithContext(ctx, "POST", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	assert.Equal(t, "fixed-key", got)
+}
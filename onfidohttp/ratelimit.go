@@ -0,0 +1,98 @@
+package onfidohttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/getground/go-onfido"
+)
+
+// RateLimiterOptions configures the RateLimit middleware.
+type RateLimiterOptions struct {
+	// RequestsPerSecond is the sustained rate at which requests are let
+	// through.
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests allowed to proceed
+	// immediately before throttling kicks in. Defaults to 1.
+	Burst int
+}
+
+// RateLimit throttles outgoing requests to at most RequestsPerSecond,
+// allowing short bursts up to Burst, using a token bucket. This is intended
+// to keep a client within Onfido's per-account rate limits.
+func RateLimit(opts RateLimiterOptions) onfido.Middleware {
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+	bucket := &tokenBucket{
+		rate:   opts.RequestsPerSecond,
+		burst:  float64(opts.Burst),
+		tokens: float64(opts.Burst),
+		last:   time.Now(),
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := bucket.wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// tokenBucket is a minimal token-bucket rate limiter shared across
+// requests made through a single middleware instance.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens replenished per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		d := b.reserve()
+		if d <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+			continue
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve consumes a token if one is available and returns zero, or
+// otherwise returns the delay until one will be.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
@@ -0,0 +1,44 @@
+package onfidohttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getground/go-onfido"
+	"github.com/getground/go-onfido/onfidohttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_ThrottlesToConfiguredRate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := onfido.NewClient("123")
+	client.Endpoint = srv.URL
+	client.Use(onfidohttp.RateLimit(onfidohttp.RateLimiterOptions{
+		RequestsPerSecond: 100,
+		Burst:             1,
+	}))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest("GET", srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.HTTPClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	elapsed := time.Since(start)
+
+	// 3 requests with burst 1 at 100rps means 2 requests must wait ~10ms
+	// each, so this should take noticeably longer than an unthrottled loop.
+	assert.GreaterOrEqual(t, elapsed, 15*time.Millisecond)
+}
@@ -0,0 +1,13 @@
+// Package onfidohttp provides pluggable onfido.Middleware implementations
+// for the go-onfido client: retry with backoff, rate limiting, idempotency
+// key injection, and request/response hooks.
+package onfidohttp
+
+import "net/http"
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,33 @@
+package onfido
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by a DocumentStore when the requested key does
+// not exist.
+var ErrNotFound = errors.New("onfido: key not found in store")
+
+// Meta describes a blob held in a DocumentStore.
+type Meta struct {
+	ContentType string
+	Size        int64
+	ETag        string
+	ModTime     time.Time
+}
+
+// DocumentStore is a pluggable cache backend for downloaded documents and
+// live photos, keyed by "applicantID/documentID" (or the live photo ID).
+// See FileStore for a ready-to-use implementation, S3Store for a
+// bring-your-own-client adapter over an S3-compatible object store, and
+// Client.WithCache for wiring a store into DownloadDocument and
+// GetLivePhotoDownload.
+type DocumentStore interface {
+	Put(ctx context.Context, key string, r io.Reader, meta Meta) error
+	Get(ctx context.Context, key string) (io.ReadCloser, Meta, error)
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (Meta, error)
+}
@@ -0,0 +1,105 @@
+package onfido
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileStore is a DocumentStore backed by the local filesystem. Each blob is
+// stored as a file under Dir, alongside a small JSON sidecar file holding
+// its Meta.
+type FileStore struct {
+	Dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating it if it doesn't
+// already exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (s *FileStore) paths(key string) (blob, meta string) {
+	blob = filepath.Join(s.Dir, filepath.FromSlash(key))
+	return blob, blob + ".meta.json"
+}
+
+// Put implements DocumentStore.
+func (s *FileStore) Put(ctx context.Context, key string, r io.Reader, meta Meta) error {
+	blobPath, metaPath := s.paths(key)
+	if err := os.MkdirAll(filepath.Dir(blobPath), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(blobPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+
+	meta.ModTime = time.Now()
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(metaPath, data, 0o644)
+}
+
+// Get implements DocumentStore.
+func (s *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, Meta, error) {
+	meta, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	blobPath, _ := s.paths(key)
+	f, err := os.Open(blobPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, Meta{}, ErrNotFound
+		}
+		return nil, Meta{}, err
+	}
+	return f, meta, nil
+}
+
+// Delete implements DocumentStore.
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	blobPath, metaPath := s.paths(key)
+	if err := os.Remove(blobPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(metaPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Stat implements DocumentStore.
+func (s *FileStore) Stat(ctx context.Context, key string) (Meta, error) {
+	_, metaPath := s.paths(key)
+	data, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Meta{}, ErrNotFound
+		}
+		return Meta{}, err
+	}
+
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Meta{}, err
+	}
+	return meta, nil
+}
@@ -0,0 +1,67 @@
+package onfido_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/getground/go-onfido"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore_PutGetStatDelete(t *testing.T) {
+	store, err := onfido.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	key := "541d040b-89f8-444b-8921-16b1333bf1c6/ce62d838-56f8-4ea5-98be-e7166d1dc33d"
+	content := []byte("hello world")
+
+	err = store.Put(ctx, key, bytes.NewReader(content), onfido.Meta{
+		ContentType: "image/png",
+		Size:        int64(len(content)),
+		ETag:        `"abc123"`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := store.Stat(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `"abc123"`, meta.ETag)
+	assert.Equal(t, int64(len(content)), meta.Size)
+
+	r, meta, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, content, got)
+	assert.Equal(t, `"abc123"`, meta.ETag)
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Stat(ctx, key)
+	assert.Equal(t, onfido.ErrNotFound, err)
+}
+
+func TestFileStore_StatMissingKey(t *testing.T) {
+	store, err := onfido.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = store.Stat(context.Background(), "does/not-exist")
+	assert.Equal(t, onfido.ErrNotFound, err)
+}
@@ -0,0 +1,62 @@
+package onfido
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// S3Client is the minimal subset of an S3-compatible client that S3Store
+// needs to talk to the object store. This package does not implement the
+// S3 wire protocol itself, so as not to force an AWS SDK (or any other)
+// dependency on callers who don't need this backend: S3Store is a
+// bring-your-own-client shim, and callers must supply an S3Client, e.g. a
+// small adapter over aws-sdk-go-v2's s3.Client or a MinIO client.
+type S3Client interface {
+	PutObject(ctx context.Context, bucket, key string, body io.Reader, meta Meta) error
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, Meta, error)
+	DeleteObject(ctx context.Context, bucket, key string) error
+	HeadObject(ctx context.Context, bucket, key string) (Meta, error)
+}
+
+// S3Store is a DocumentStore that delegates to a caller-supplied
+// S3-compatible client, storing blobs under Prefix within Bucket. See the
+// S3Client doc comment: this type does not speak the S3 protocol itself.
+type S3Store struct {
+	Client S3Client
+	Bucket string
+	Prefix string
+}
+
+// NewS3Store returns a S3Store that stores blobs in bucket under prefix,
+// using client to talk to the object store.
+func NewS3Store(client S3Client, bucket, prefix string) *S3Store {
+	return &S3Store{Client: client, Bucket: bucket, Prefix: prefix}
+}
+
+func (s *S3Store) objectKey(key string) string {
+	if s.Prefix == "" {
+		return key
+	}
+	return strings.TrimSuffix(s.Prefix, "/") + "/" + key
+}
+
+// Put implements DocumentStore.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, meta Meta) error {
+	return s.Client.PutObject(ctx, s.Bucket, s.objectKey(key), r, meta)
+}
+
+// Get implements DocumentStore.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, Meta, error) {
+	return s.Client.GetObject(ctx, s.Bucket, s.objectKey(key))
+}
+
+// Delete implements DocumentStore.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	return s.Client.DeleteObject(ctx, s.Bucket, s.objectKey(key))
+}
+
+// Stat implements DocumentStore.
+func (s *S3Store) Stat(ctx context.Context, key string) (Meta, error) {
+	return s.Client.HeadObject(ctx, s.Bucket, s.objectKey(key))
+}
@@ -0,0 +1,25 @@
+package webhook
+
+// CheckCompletedEvent is the payload.object of a check.completed event.
+type CheckCompletedEvent struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	Result      string `json:"result,omitempty"`
+	Href        string `json:"href"`
+	CompletedAt string `json:"completed_at_iso8601,omitempty"`
+}
+
+// ReportCompletedEvent is the payload.object of a report.completed event.
+type ReportCompletedEvent struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+	Result string `json:"result,omitempty"`
+	Href   string `json:"href"`
+}
+
+// DocumentCreatedEvent is the payload.object of a document.created event.
+type DocumentCreatedEvent struct {
+	ID   string `json:"id"`
+	Href string `json:"href"`
+}
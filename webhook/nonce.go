@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// nonceCache tracks recently seen event keys to guard against Onfido
+// redelivering the same webhook callback more than once.
+type nonceCache struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	seenAt map[string]time.Time
+}
+
+func newNonceCache(ttl time.Duration) *nonceCache {
+	return &nonceCache{
+		ttl:    ttl,
+		seenAt: make(map[string]time.Time),
+	}
+}
+
+// check reports whether key has not already been recorded within ttl. It
+// does not itself record key; callers that go on to process the event
+// must call record once processing succeeds.
+func (c *nonceCache) check(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range c.seenAt {
+		if now.Sub(t) >= c.ttl {
+			delete(c.seenAt, k)
+		}
+	}
+
+	_, ok := c.seenAt[key]
+	return !ok
+}
+
+// record marks key as seen as of now.
+func (c *nonceCache) record(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seenAt[key] = time.Now()
+}
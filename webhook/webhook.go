@@ -0,0 +1,167 @@
+// Package webhook implements an http.Handler that receives Onfido webhook
+// callbacks: it verifies the X-SHA2-Signature header against the raw
+// request body, decodes the event envelope, and dispatches it to handlers
+// registered per action.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// SignatureHeader is the HTTP header Onfido sets with the HMAC-SHA256
+// signature of the raw request body, hex-encoded.
+const SignatureHeader = "X-SHA2-Signature"
+
+var (
+	// ErrInvalidSignature's message is the body of the 401 response Mux
+	// sends when a request's signature header does not match the
+	// HMAC-SHA256 of its body computed with the configured token.
+	ErrInvalidSignature = errors.New("onfido/webhook: invalid signature")
+	// ErrReplayed's message is the body of the 409 response Mux sends when
+	// replay protection is enabled and the event has already been processed
+	// within its TTL.
+	ErrReplayed = errors.New("onfido/webhook: event already processed")
+)
+
+// Event is the envelope Onfido sends for every webhook callback.
+type Event struct {
+	Payload struct {
+		ResourceType string          `json:"resource_type"`
+		Action       string          `json:"action"`
+		Object       json.RawMessage `json:"object"`
+	} `json:"payload"`
+}
+
+// Mux dispatches verified webhook events to handlers registered per action.
+// The zero value is not usable; construct one with New.
+type Mux struct {
+	token    string
+	handlers map[string]reflect.Value
+	nonces   *nonceCache
+}
+
+// New returns a Mux that verifies incoming requests using token, the
+// webhook token issued for this webhook in the Onfido dashboard.
+func New(token string) *Mux {
+	return &Mux{
+		token:    token,
+		handlers: make(map[string]reflect.Value),
+	}
+}
+
+// WithReplayProtection enables a nonce cache that rejects an action/object
+// pair whose handler already succeeded within ttl, and returns m for
+// chaining. Deliveries whose handler failed, or whose action has no
+// handler, are not recorded and are accepted again.
+func (m *Mux) WithReplayProtection(ttl time.Duration) *Mux {
+	m.nonces = newNonceCache(ttl)
+	return m
+}
+
+// On registers handler to be called for events whose action matches, e.g.
+// "check.completed". handler must be a func(context.Context, T) error; On
+// panics if it does not have that shape. T is only checked at dispatch:
+// if the event's payload.object cannot be unmarshalled into T, ServeHTTP
+// responds 400 Bad Request and handler is not called.
+func (m *Mux) On(action string, handler interface{}) {
+	v := reflect.ValueOf(handler)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 2 || t.NumOut() != 1 ||
+		t.In(0) != reflect.TypeOf((*context.Context)(nil)).Elem() ||
+		t.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
+		panic("webhook: handler must be a func(context.Context, T) error")
+	}
+	m.handlers[action] = v
+}
+
+// ServeHTTP verifies the request's signature, decodes the event, and
+// dispatches it to the handler registered for its action, if any.
+// Unrecognised actions are acknowledged with 200 OK so Onfido does not
+// retry them.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "unable to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if !verifySignature(m.token, body, r.Header.Get(SignatureHeader)) {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, "unable to decode event", http.StatusBadRequest)
+		return
+	}
+
+	var nonceKey string
+	if m.nonces != nil {
+		nonceKey = replayKey(event)
+		if !m.nonces.check(nonceKey) {
+			http.Error(w, ErrReplayed.Error(), http.StatusConflict)
+			return
+		}
+	}
+
+	handler, ok := m.handlers[event.Payload.Action]
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	arg := reflect.New(handler.Type().In(1))
+	if err := json.Unmarshal(event.Payload.Object, arg.Interface()); err != nil {
+		http.Error(w, "unable to decode event object", http.StatusBadRequest)
+		return
+	}
+
+	out := handler.Call([]reflect.Value{reflect.ValueOf(r.Context()), arg.Elem()})
+	if errVal, _ := out[0].Interface().(error); errVal != nil {
+		// Leave the nonce unrecorded so Onfido's redelivery of this
+		// failed event is not rejected as a replay.
+		http.Error(w, errVal.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if m.nonces != nil {
+		m.nonces.record(nonceKey)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// replayKey derives the nonce cache key for event: the action plus the
+// object's id, or, when the object carries no usable id, the action plus
+// a hash of the raw object so that distinct malformed or id-less events
+// don't collide on the same key.
+func replayKey(event Event) string {
+	var obj struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(event.Payload.Object, &obj); err == nil && obj.ID != "" {
+		return event.Payload.Action + ":" + obj.ID
+	}
+	sum := sha256.Sum256(event.Payload.Object)
+	return event.Payload.Action + ":" + hex.EncodeToString(sum[:])
+}
+
+func computeSignature(token string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifySignature(token string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(computeSignature(token, body)), []byte(signature))
+}
@@ -0,0 +1,138 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getground/go-onfido/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func payload(action string, object interface{}) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"payload": map[string]interface{}{
+			"resource_type": "check",
+			"action":        action,
+			"object":        object,
+		},
+	})
+	return body
+}
+
+func TestMux_ServeHTTP_InvalidSignature(t *testing.T) {
+	mux := webhook.New("secret")
+
+	body := payload("check.completed", webhook.CheckCompletedEvent{ID: "1"})
+	req, err := webhook.NewSignedRequest("POST", "/", "wrong-secret", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, 401, rec.Code)
+}
+
+func TestMux_ServeHTTP_DispatchesToHandler(t *testing.T) {
+	mux := webhook.New("secret")
+
+	var got webhook.CheckCompletedEvent
+	mux.On("check.completed", func(ctx context.Context, e webhook.CheckCompletedEvent) error {
+		got = e
+		return nil
+	})
+
+	body := payload("check.completed", webhook.CheckCompletedEvent{ID: "check-1", Status: "complete"})
+	req, err := webhook.NewSignedRequest("POST", "/", "secret", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "check-1", got.ID)
+	assert.Equal(t, "complete", got.Status)
+}
+
+func TestMux_ServeHTTP_UnrecognisedActionIsAcknowledged(t *testing.T) {
+	mux := webhook.New("secret")
+
+	body := payload("check.started", webhook.CheckCompletedEvent{ID: "check-1"})
+	req, err := webhook.NewSignedRequest("POST", "/", "secret", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, 200, rec.Code)
+}
+
+func TestMux_ServeHTTP_ReplayProtection(t *testing.T) {
+	mux := webhook.New("secret").WithReplayProtection(time.Minute)
+
+	var calls int
+	mux.On("check.completed", func(ctx context.Context, e webhook.CheckCompletedEvent) error {
+		calls++
+		return nil
+	})
+
+	body := payload("check.completed", webhook.CheckCompletedEvent{ID: "check-1"})
+
+	for i := 0; i < 2; i++ {
+		req, err := webhook.NewSignedRequest("POST", "/", "secret", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if i == 0 {
+			assert.Equal(t, 200, rec.Code)
+		} else {
+			assert.Equal(t, 409, rec.Code)
+		}
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestMux_ServeHTTP_ReplayProtectionAllowsRetryAfterHandlerError(t *testing.T) {
+	mux := webhook.New("secret").WithReplayProtection(time.Minute)
+
+	var calls int
+	mux.On("check.completed", func(ctx context.Context, e webhook.CheckCompletedEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transient failure")
+		}
+		return nil
+	})
+
+	body := payload("check.completed", webhook.CheckCompletedEvent{ID: "check-1"})
+
+	for i := 0; i < 2; i++ {
+		req, err := webhook.NewSignedRequest("POST", "/", "secret", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if i == 0 {
+			assert.Equal(t, 500, rec.Code)
+		} else {
+			assert.Equal(t, 200, rec.Code)
+		}
+	}
+
+	assert.Equal(t, 2, calls)
+}